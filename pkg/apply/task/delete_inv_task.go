@@ -19,6 +19,11 @@ type DeleteInvTask struct {
 	TaskName  string
 	InvClient inventory.InventoryClient
 	InvInfo   inventory.InventoryInfo
+	// PreserveResourcesOnDeletion, when true, leaves all previously-tracked
+	// resources untouched in the cluster instead of pruning them. The
+	// inventory object itself is still removed, allowing a subsequent apply
+	// with a fresh inventory to re-adopt the same resources.
+	PreserveResourcesOnDeletion bool
 }
 
 func (i *DeleteInvTask) Name() string {
@@ -33,11 +38,19 @@ func (i *DeleteInvTask) Identifiers() []object.ObjMetadata {
 	return []object.ObjMetadata{}
 }
 
-// Start deletes the inventory object from the cluster.
+// Start deletes the inventory object from the cluster. If
+// PreserveResourcesOnDeletion is set, pruning of the tracked resources is
+// skipped entirely and the inventory object is removed in a way that leaves
+// those resources running.
 func (i *DeleteInvTask) Start(taskContext *taskrunner.TaskContext) {
 	go func() {
-		klog.V(4).Infof("delete inventory object (%s/%s)", i.InvInfo.Namespace(), i.InvInfo.Name())
-		err := i.InvClient.DeleteInventoryObj(i.InvInfo)
+		if i.PreserveResourcesOnDeletion {
+			klog.V(4).Infof("delete inventory object (%s/%s), preserving tracked resources",
+				i.InvInfo.Namespace(), i.InvInfo.Name())
+		} else {
+			klog.V(4).Infof("delete inventory object (%s/%s)", i.InvInfo.Namespace(), i.InvInfo.Name())
+		}
+		err := i.InvClient.DeleteInventoryObj(i.InvInfo, i.PreserveResourcesOnDeletion)
 		taskContext.TaskChannel() <- taskrunner.TaskResult{Err: err}
 	}()
 }
@@ -4,11 +4,15 @@
 package inventory
 
 import (
+	"encoding/json"
 	"fmt"
 	"sort"
 
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/client-go/util/retry"
 	"k8s.io/klog"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"k8s.io/kubectl/pkg/validation"
@@ -17,6 +21,53 @@ import (
 	"sigs.k8s.io/cli-utils/pkg/ordering"
 )
 
+// InventoryPolicy configures optional inventory behaviors that change how
+// the inventory object relates to the resources it tracks.
+type InventoryPolicy struct {
+	// OwnerRefs, when true, causes the inventory client to set a Kubernetes
+	// OwnerReference on every applied resource pointing back to the
+	// inventory object, and to rely on native garbage collection to remove
+	// those resources when the inventory object is deleted, instead of the
+	// applier's own prune loop.
+	OwnerRefs bool
+	// PropagationPolicy controls how deleting the inventory object cascades
+	// to the resources it owns when OwnerRefs is enabled. Defaults to
+	// metav1.DeletePropagationBackground if unset.
+	PropagationPolicy metav1.DeletionPropagation
+	// ServerSideApply, when true, writes the inventory object using
+	// Kubernetes server-side apply instead of a traditional read-modify-write
+	// Update, so multiple appliers can co-own the inventory object with
+	// per-field ownership tracked by the APIServer.
+	ServerSideApply bool
+	// FieldManager is the field manager name used for server-side apply
+	// writes to the inventory object when ServerSideApply is enabled.
+	// Defaults to defaultInventoryFieldManager if unset.
+	FieldManager string
+}
+
+// defaultInventoryFieldManager is the field manager name used for
+// server-side apply writes to the inventory object when the caller has not
+// configured one explicitly.
+const defaultInventoryFieldManager = "cli-utils-inventory"
+
+// propagationPolicy returns the configured deletion propagation policy,
+// defaulting to background propagation if the caller did not set one.
+func (p InventoryPolicy) propagationPolicy() metav1.DeletionPropagation {
+	if p.PropagationPolicy == "" {
+		return metav1.DeletePropagationBackground
+	}
+	return p.PropagationPolicy
+}
+
+// fieldManager returns the configured server-side apply field manager name,
+// defaulting to defaultInventoryFieldManager if the caller did not set one.
+func (p InventoryPolicy) fieldManager() string {
+	if p.FieldManager == "" {
+		return defaultInventoryFieldManager
+	}
+	return p.FieldManager
+}
+
 // InventoryClient expresses an interface for interacting with
 // objects which store references to objects (inventory objects).
 type InventoryClient interface {
@@ -33,7 +84,11 @@ type InventoryClient interface {
 	// object with the passed set of objects, or an error if one occurs.
 	Replace(inv *resource.Info, objs []object.ObjMetadata) error
 	// DeleteInventoryObj deletes the passed inventory object from the APIServer.
-	DeleteInventoryObj(inv *resource.Info) error
+	// If preserveResourcesOnDeletion is true, implementations must ensure the
+	// previously-tracked resources are left untouched in the cluster, even if
+	// that means avoiding any owner-reference-based cascading deletion they
+	// would otherwise rely on.
+	DeleteInventoryObj(inv *resource.Info, preserveResourcesOnDeletion bool) error
 	// SetDryRun sets the boolean on whether this we actually mutate.
 	SetDryRun(dryRun bool)
 }
@@ -46,6 +101,7 @@ type ClusterInventoryClient struct {
 	validator   validation.Schema
 	clientFunc  func(*meta.RESTMapping) (resource.RESTClient, error)
 	dryRun      bool
+	policy      InventoryPolicy
 }
 
 var _ InventoryClient = &ClusterInventoryClient{}
@@ -100,31 +156,65 @@ func (cic *ClusterInventoryClient) Merge(localInv *resource.Info, objs []object.
 		if err := cic.createInventoryObj(invInfo); err != nil {
 			return nil, err
 		}
+		if cic.policy.OwnerRefs && !cic.dryRun {
+			if err := cic.patchOwnerReferences(invInfo, objs); err != nil {
+				return nil, err
+			}
+		}
 	} else {
-		// Update existing cluster inventory with merged union of objects
-		clusterObjs, err := cic.GetClusterObjs(localInv)
+		// Update existing cluster inventory with merged union of objects.
+		// Retried on conflict: another applier may race us to update the
+		// same inventory object, so each attempt re-reads the current
+		// cluster inventory and recomputes the union and prune set against
+		// that freshly observed revision before writing.
+		var unionObjs []object.ObjMetadata
+		err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+			current, rerr := cic.getClusterInventoryInfo(localInv)
+			if rerr != nil {
+				return rerr
+			}
+			if current == nil {
+				return fmt.Errorf("cluster inventory object removed while merging")
+			}
+			clusterObjs, rerr := WrapInventoryObj(current).Load()
+			if rerr != nil {
+				return rerr
+			}
+			if object.SetEquals(objs, clusterObjs) {
+				klog.V(4).Infof("applied objects same as cluster inventory: do nothing")
+				pruneIds = []object.ObjMetadata{}
+				unionObjs = clusterObjs
+				clusterInv = current
+				return nil
+			}
+			pruneIds = object.SetDiff(clusterObjs, objs)
+			unionObjs = object.Union(clusterObjs, objs)
+			klog.V(4).Infof("num objects to prune: %d", len(pruneIds))
+			klog.V(4).Infof("num merged objects to store in inventory: %d", len(unionObjs))
+			wrappedInv := WrapInventoryObj(current)
+			if rerr = wrappedInv.Store(unionObjs); rerr != nil {
+				return rerr
+			}
+			if cic.dryRun {
+				clusterInv = current
+				return nil
+			}
+			updated, rerr := wrappedInv.GetObject()
+			if rerr != nil {
+				return rerr
+			}
+			klog.V(4).Infof("update cluster inventory: %s/%s", updated.Namespace, updated.Name)
+			if rerr := cic.applyInventoryObj(updated); rerr != nil {
+				return rerr
+			}
+			clusterInv = updated
+			return nil
+		})
 		if err != nil {
 			return pruneIds, err
 		}
-		if object.SetEquals(objs, clusterObjs) {
-			klog.V(4).Infof("applied objects same as cluster inventory: do nothing")
-			return pruneIds, nil
-		}
-		pruneIds = object.SetDiff(clusterObjs, objs)
-		unionObjs := object.Union(clusterObjs, objs)
-		klog.V(4).Infof("num objects to prune: %d", len(pruneIds))
-		klog.V(4).Infof("num merged objects to store in inventory: %d", len(unionObjs))
-		wrappedInv := WrapInventoryObj(clusterInv)
-		if err = wrappedInv.Store(unionObjs); err != nil {
-			return pruneIds, err
-		}
-		if !cic.dryRun {
-			clusterInv, err = wrappedInv.GetObject()
-			if err != nil {
-				return pruneIds, err
-			}
-			klog.V(4).Infof("update cluster inventory: %s/%s", clusterInv.Namespace, clusterInv.Name)
-			if err := cic.applyInventoryObj(clusterInv); err != nil {
+		if cic.policy.OwnerRefs && !cic.dryRun {
+			if err := cic.patchOwnerReferences(clusterInv, unionObjs); err != nil {
 				return pruneIds, err
 			}
 		}
@@ -134,36 +224,44 @@ func (cic *ClusterInventoryClient) Merge(localInv *resource.Info, objs []object.
 }
 
 // Replace stores the passed objects in the cluster inventory object, or
-// an error if one occurred.
+// an error if one occurred. Retried on conflict: each attempt re-reads the
+// current cluster inventory before writing, so a concurrent update from
+// another applier causes a fresh retry rather than a clobbered write.
 func (cic *ClusterInventoryClient) Replace(localInv *resource.Info, objs []object.ObjMetadata) error {
-	clusterObjs, err := cic.GetClusterObjs(localInv)
-	if err != nil {
-		return err
-	}
-	if object.SetEquals(objs, clusterObjs) {
-		klog.V(4).Infof("applied objects same as cluster inventory: do nothing")
-		return nil
-	}
-	clusterInv, err := cic.getClusterInventoryInfo(localInv)
-	if err != nil {
-		return err
-	}
-	wrappedInv := WrapInventoryObj(clusterInv)
-	if err = wrappedInv.Store(objs); err != nil {
-		return err
-	}
-	if !cic.dryRun {
-		clusterInv, err = wrappedInv.GetObject()
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		clusterObjs, err := cic.GetClusterObjs(localInv)
+		if err != nil {
+			return err
+		}
+		if object.SetEquals(objs, clusterObjs) {
+			klog.V(4).Infof("applied objects same as cluster inventory: do nothing")
+			return nil
+		}
+		clusterInv, err := cic.getClusterInventoryInfo(localInv)
+		if err != nil {
+			return err
+		}
+		wrappedInv := WrapInventoryObj(clusterInv)
+		if err = wrappedInv.Store(objs); err != nil {
+			return err
+		}
+		if cic.dryRun {
+			return nil
+		}
+		updated, err := wrappedInv.GetObject()
 		if err != nil {
 			return err
 		}
-		klog.V(4).Infof("replace cluster inventory: %s/%s", clusterInv.Namespace, clusterInv.Name)
+		klog.V(4).Infof("replace cluster inventory: %s/%s", updated.Namespace, updated.Name)
 		klog.V(4).Infof("replace cluster inventory %d objects", len(objs))
-		if err := cic.applyInventoryObj(clusterInv); err != nil {
+		if err := cic.applyInventoryObj(updated); err != nil {
 			return err
 		}
-	}
-	return nil
+		if cic.policy.OwnerRefs {
+			return cic.patchOwnerReferences(updated, objs)
+		}
+		return nil
+	})
 }
 
 // GetClusterObjs returns the objects stored in the cluster inventory object, or
@@ -270,33 +368,176 @@ func (cic *ClusterInventoryClient) mergeClusterInventory(invInfos []*resource.In
 		}
 		retainedObjs = object.Union(retainedObjs, mergeObjs)
 	}
-	if err := wrapRetained.Store(retainedObjs); err != nil {
-		return nil, err
-	}
-	retainInfo, err := wrapRetained.GetObject()
-	if err != nil {
-		return nil, err
-	}
-	// Store the merged inventory into the one retained inventory
-	// object.
+	// Store the merged inventory into the one retained inventory object.
+	// Retried on conflict: each attempt re-reads the retained object's
+	// current resourceVersion before writing, since retainedObjs is already
+	// the fixed union of the other inventory objects being merged away.
 	//
 	// IMPORTANT: This must happen BEFORE deleting the other
 	// inventory objects, in order to ensure we always have
 	// access to the union of the inventory.
-	if err := cic.applyInventoryObj(retainInfo); err != nil {
+	var retainInfo *resource.Info
+	err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		if rerr := cic.refreshInventoryInfo(retained); rerr != nil {
+			return rerr
+		}
+		if rerr := wrapRetained.Store(retainedObjs); rerr != nil {
+			return rerr
+		}
+		info, rerr := wrapRetained.GetObject()
+		if rerr != nil {
+			return rerr
+		}
+		if rerr := cic.applyInventoryObj(info); rerr != nil {
+			return rerr
+		}
+		retainInfo = info
+		return nil
+	})
+	if err != nil {
 		return nil, err
 	}
 	// Finally, delete the other inventory objects.
+	// Their resources are now tracked by the retained inventory object, so
+	// they are preserved.
 	for i := 1; i < len(invInfos); i++ {
 		merge := invInfos[i]
-		if err := cic.DeleteInventoryObj(merge); err != nil {
+		if err := cic.DeleteInventoryObj(merge, true); err != nil {
 			return nil, err
 		}
 	}
 	return retainInfo, nil
 }
 
-// applyInventoryObj applies the passed inventory object to the APIServer.
+// ownerReferenceForInventory builds the owner reference that should be set
+// on resources tracked by the passed inventory object, pointing at its
+// current name and UID. Recreating the inventory object (e.g. after it was
+// deleted) produces a new UID, so resources still carrying the reference
+// from a prior incarnation are no longer considered owned by the new one.
+func ownerReferenceForInventory(invInfo *resource.Info) (metav1.OwnerReference, error) {
+	invAccessor, err := meta.Accessor(invInfo.Object)
+	if err != nil {
+		return metav1.OwnerReference{}, err
+	}
+	controller := false
+	blockOwnerDeletion := false
+	return metav1.OwnerReference{
+		APIVersion:         invInfo.Mapping.GroupVersionKind.GroupVersion().String(),
+		Kind:               invInfo.Mapping.GroupVersionKind.Kind,
+		Name:               invAccessor.GetName(),
+		UID:                invAccessor.GetUID(),
+		Controller:         &controller,
+		BlockOwnerDeletion: &blockOwnerDeletion,
+	}, nil
+}
+
+// upsertOwnerReference returns existing with the entry referring to the same
+// owner as ref (matched by APIVersion, Kind and Name, ignoring UID) replaced
+// by ref, or ref appended if no such entry is present. Matching ignores UID
+// because the inventory object's UID changes every time it is deleted and
+// recreated, while its namespace/name stays the owner's stable identity.
+// Entries belonging to any other owner (e.g. a different controller) are
+// passed through untouched.
+func upsertOwnerReference(existing []metav1.OwnerReference, ref metav1.OwnerReference) []metav1.OwnerReference {
+	for i := range existing {
+		if existing[i].APIVersion == ref.APIVersion &&
+			existing[i].Kind == ref.Kind &&
+			existing[i].Name == ref.Name {
+			merged := make([]metav1.OwnerReference, len(existing))
+			copy(merged, existing)
+			merged[i] = ref
+			return merged
+		}
+	}
+	return append(existing, ref)
+}
+
+// isFallbackTrackedObj reports whether obj cannot carry an owner reference
+// back to the inventory object and so must rely on in-body inventory
+// tracking instead of owner-reference-based garbage collection: a
+// cluster-scoped object cannot carry an owner reference to a namespaced
+// inventory object (this is rejected by the APIServer).
+func isFallbackTrackedObj(invNamespace string, obj object.ObjMetadata) bool {
+	return obj.Namespace == "" && invNamespace != ""
+}
+
+// patchOwnerReferences sets an owner reference pointing back to the
+// inventory object on each of the passed objects, so that deleting the
+// inventory object triggers native Kubernetes garbage collection of the
+// resources it tracks. A cluster-scoped object cannot carry an owner
+// reference to a namespaced inventory object (this is rejected by the
+// APIServer), so such objects are skipped in favor of the in-body inventory
+// storage/prune path, with a warning.
+//
+// Each object's current ownerReferences are fetched and the inventory's
+// entry is upserted into that slice rather than replacing it outright: a
+// JSON merge patch replaces the whole ownerReferences array, so patching in
+// a single-element slice would silently wipe out any reference the object
+// already carries to another owner (e.g. a different controller, or a prior
+// co-owning inventory).
+//
+// The patch is a JSON merge patch rather than a strategic merge patch: the
+// APIServer only carries strategic-merge metadata for compiled-in built-in
+// types, and rejects strategic merge patches for CRDs and other custom
+// resources, which cli-utils must also be able to manage.
+func (cic *ClusterInventoryClient) patchOwnerReferences(invInfo *resource.Info, objs []object.ObjMetadata) error {
+	invObjMeta, err := object.InfoToObjMeta(invInfo)
+	if err != nil {
+		return err
+	}
+	ownerRef, err := ownerReferenceForInventory(invInfo)
+	if err != nil {
+		return err
+	}
+	for _, obj := range objs {
+		if isFallbackTrackedObj(invObjMeta.Namespace, obj) {
+			klog.Warningf("cannot set owner reference on cluster-scoped object (%s) from namespaced inventory (%s/%s): falling back to in-body inventory storage",
+				obj, invObjMeta.Namespace, invObjMeta.Name)
+			continue
+		}
+		mapping, err := cic.mapper.RESTMapping(obj.GroupKind)
+		if err != nil {
+			return err
+		}
+		client, err := cic.clientFunc(mapping)
+		if err != nil {
+			return err
+		}
+		helper := resource.NewHelper(client, mapping)
+		current, err := helper.Get(obj.Namespace, obj.Name)
+		if err != nil {
+			return err
+		}
+		currentAccessor, err := meta.Accessor(current)
+		if err != nil {
+			return err
+		}
+		mergedRefs := upsertOwnerReference(currentAccessor.GetOwnerReferences(), ownerRef)
+		patch, err := json.Marshal(map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"ownerReferences": mergedRefs,
+			},
+		})
+		if err != nil {
+			return err
+		}
+		klog.V(4).Infof("patching owner reference on object (%s) to inventory (%s/%s)", obj, invObjMeta.Namespace, invObjMeta.Name)
+		if _, err := helper.Patch(obj.Namespace, obj.Name, types.MergePatchType, patch, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyInventoryObj writes the passed inventory object to the APIServer. The
+// write is gated by the object's own resourceVersion rather than blindly
+// overwriting, so a conflicting concurrent write is rejected with a 409
+// instead of silently clobbered; callers racing on the same inventory should
+// wrap this in retry.RetryOnConflict, re-reading and recomputing before each
+// retry. If ServerSideApply is configured, the write instead goes through
+// server-side apply under the configured field manager, so multiple
+// appliers can co-own the inventory object with per-field ownership tracked
+// by the APIServer.
 func (cic *ClusterInventoryClient) applyInventoryObj(info *resource.Info) error {
 	if cic.dryRun {
 		klog.V(4).Infof("dry-run apply inventory object: not applied")
@@ -306,14 +547,58 @@ func (cic *ClusterInventoryClient) applyInventoryObj(info *resource.Info) error
 		return fmt.Errorf("attempting apply a nil inventory object")
 	}
 	helper := resource.NewHelper(info.Client, info.Mapping)
-	klog.V(4).Infof("replacing inventory object: %s/%s", info.Namespace, info.Name)
-	var overwrite = true
-	replacedObj, err := helper.Replace(info.Namespace, info.Name, overwrite, info.Object)
+	if cic.policy.ServerSideApply {
+		data, err := json.Marshal(info.Object)
+		if err != nil {
+			return err
+		}
+		force := true
+		klog.V(4).Infof("server-side apply inventory object: %s/%s (field manager %q)",
+			info.Namespace, info.Name, cic.policy.fieldManager())
+		appliedObj, err := helper.Patch(info.Namespace, info.Name, types.ApplyPatchType, data, &metav1.PatchOptions{
+			FieldManager: cic.policy.fieldManager(),
+			Force:        &force,
+		})
+		if err != nil {
+			return err
+		}
+		var ignoreError = true
+		return info.Refresh(appliedObj, ignoreError)
+	}
+	klog.V(4).Infof("updating inventory object: %s/%s", info.Namespace, info.Name)
+	var overwrite = false
+	updatedObj, err := helper.Replace(info.Namespace, info.Name, overwrite, info.Object)
 	if err != nil {
 		return err
 	}
 	var ignoreError = true
-	return info.Refresh(replacedObj, ignoreError)
+	return info.Refresh(updatedObj, ignoreError)
+}
+
+// refreshInventoryInfo re-fetches the passed inventory object from the
+// APIServer and updates info in place, including its current
+// resourceVersion. Used to re-observe the object immediately before retrying
+// a write that previously lost a conflicting-update race.
+func (cic *ClusterInventoryClient) refreshInventoryInfo(info *resource.Info) error {
+	obj, err := object.InfoToObjMeta(info)
+	if err != nil {
+		return err
+	}
+	mapping, err := cic.mapper.RESTMapping(obj.GroupKind)
+	if err != nil {
+		return err
+	}
+	client, err := cic.clientFunc(mapping)
+	if err != nil {
+		return err
+	}
+	helper := resource.NewHelper(client, mapping)
+	current, err := helper.Get(info.Namespace, info.Name)
+	if err != nil {
+		return err
+	}
+	var ignoreError = true
+	return info.Refresh(current, ignoreError)
 }
 
 // createInventoryObj creates the passed inventory object on the APIServer.
@@ -348,9 +633,13 @@ func (cic *ClusterInventoryClient) createInventoryObj(info *resource.Info) error
 	return info.Refresh(createdObj, ignoreError)
 }
 
-// DeleteInventoryObj deletes the passed inventory object from the APIServer, or
-// an error if one occurs.
-func (cic *ClusterInventoryClient) DeleteInventoryObj(info *resource.Info) error {
+// DeleteInventoryObj deletes the passed inventory object from the APIServer,
+// or an error if one occurs. The inventory object is always removed;
+// preserveResourcesOnDeletion only matters to implementations where deleting
+// it could otherwise take the resources it tracked down with it (e.g. via
+// owner-reference cascading deletion), and such implementations must avoid
+// that so a subsequently applied inventory can re-adopt the same resources.
+func (cic *ClusterInventoryClient) DeleteInventoryObj(info *resource.Info, preserveResourcesOnDeletion bool) error {
 	if cic.dryRun {
 		klog.V(4).Infof("dry-run delete inventory object: not deleted")
 		return nil
@@ -358,6 +647,15 @@ func (cic *ClusterInventoryClient) DeleteInventoryObj(info *resource.Info) error
 	if info == nil {
 		return fmt.Errorf("attempting delete a nil inventory object")
 	}
+	if cic.policy.OwnerRefs && !preserveResourcesOnDeletion {
+		// Cluster-scoped objects tracked by this (namespaced) inventory never
+		// got an owner reference patched onto them, so native GC triggered by
+		// deleting the inventory object below will never reach them; prune
+		// them directly here or they leak on every deletion.
+		if err := cic.pruneFallbackTrackedObjs(info); err != nil {
+			return err
+		}
+	}
 	obj, err := object.InfoToObjMeta(info)
 	if err != nil {
 		return err
@@ -371,13 +669,70 @@ func (cic *ClusterInventoryClient) DeleteInventoryObj(info *resource.Info) error
 		return err
 	}
 	helper := resource.NewHelper(client, mapping)
+	if preserveResourcesOnDeletion && cic.policy.OwnerRefs {
+		// The tracked resources carry owner references to this object's UID;
+		// an ordinary or background/foreground-cascading delete would let
+		// Kubernetes' garbage collector take them down with it. Orphan
+		// propagation strips those owner references instead, so the
+		// resources keep running and can be re-adopted later.
+		orphan := metav1.DeletePropagationOrphan
+		klog.V(4).Infof("deleting inventory object with orphan propagation to preserve owned resources: %s/%s", info.Namespace, info.Name)
+		_, err = helper.DeleteWithOptions(info.Namespace, info.Name, &metav1.DeleteOptions{PropagationPolicy: &orphan})
+		return err
+	}
+	if cic.policy.OwnerRefs {
+		propagationPolicy := cic.policy.propagationPolicy()
+		klog.V(4).Infof("deleting inventory object, cascading to owned resources with %s propagation: %s/%s",
+			propagationPolicy, info.Namespace, info.Name)
+		_, err = helper.DeleteWithOptions(info.Namespace, info.Name, &metav1.DeleteOptions{PropagationPolicy: &propagationPolicy})
+		return err
+	}
 	klog.V(4).Infof("deleting inventory object: %s/%s", info.Namespace, info.Name)
 	_, err = helper.Delete(info.Namespace, info.Name)
 	return err
 }
 
+// pruneFallbackTrackedObjs deletes the tracked objects that patchOwnerReferences
+// could not attach an owner reference to (cluster-scoped objects tracked by a
+// namespaced inventory, per isFallbackTrackedObj). Those objects are never
+// reachable by owner-reference-based garbage collection, so they must be
+// deleted directly instead of relying on the inventory object's own deletion
+// to clean them up.
+func (cic *ClusterInventoryClient) pruneFallbackTrackedObjs(info *resource.Info) error {
+	objs, err := cic.GetClusterObjs(info)
+	if err != nil {
+		return err
+	}
+	for _, obj := range objs {
+		if !isFallbackTrackedObj(info.Namespace, obj) {
+			continue
+		}
+		mapping, err := cic.mapper.RESTMapping(obj.GroupKind)
+		if err != nil {
+			return err
+		}
+		client, err := cic.clientFunc(mapping)
+		if err != nil {
+			return err
+		}
+		helper := resource.NewHelper(client, mapping)
+		klog.V(4).Infof("pruning cluster-scoped tracked object not reachable by owner-reference GC: %s", obj)
+		if _, err := helper.Delete(obj.Namespace, obj.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // SetDryRun sets whether the inventory client will mutate the inventory
 // object in the cluster.
 func (cic *ClusterInventoryClient) SetDryRun(dryRun bool) {
 	cic.dryRun = dryRun
 }
+
+// SetInventoryPolicy configures optional inventory behaviors, such as
+// backing the inventory with Kubernetes OwnerReferences and native garbage
+// collection instead of the applier's own prune loop.
+func (cic *ClusterInventoryClient) SetInventoryPolicy(policy InventoryPolicy) {
+	cic.policy = policy
+}
@@ -0,0 +1,202 @@
+// Copyright 2021 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest/fake"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+func fakeInventoryInfo(namespace, name string, uid types.UID) *resource.Info {
+	u := &unstructured.Unstructured{}
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	u.SetUID(uid)
+	return &resource.Info{
+		Namespace: namespace,
+		Name:      name,
+		Object:    u,
+		Mapping: &meta.RESTMapping{
+			GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+		},
+	}
+}
+
+func TestOwnerReferenceForInventory_UIDChangesOnRecreation(t *testing.T) {
+	original := fakeInventoryInfo("test-ns", "inventory", types.UID("original-uid"))
+	originalRef, err := ownerReferenceForInventory(original)
+	if err != nil {
+		t.Fatalf("ownerReferenceForInventory() error = %v", err)
+	}
+	if originalRef.UID != "original-uid" {
+		t.Errorf("originalRef.UID = %q, want %q", originalRef.UID, "original-uid")
+	}
+	if originalRef.Name != "inventory" {
+		t.Errorf("originalRef.Name = %q, want %q", originalRef.Name, "inventory")
+	}
+
+	// Simulate the inventory object being deleted and recreated: same name
+	// and namespace, but a new UID assigned by the APIServer.
+	recreated := fakeInventoryInfo("test-ns", "inventory", types.UID("recreated-uid"))
+	recreatedRef, err := ownerReferenceForInventory(recreated)
+	if err != nil {
+		t.Fatalf("ownerReferenceForInventory() error = %v", err)
+	}
+	if recreatedRef.UID != "recreated-uid" {
+		t.Errorf("recreatedRef.UID = %q, want %q", recreatedRef.UID, "recreated-uid")
+	}
+	if recreatedRef.UID == originalRef.UID {
+		t.Errorf("owner reference UID did not change across inventory recreation; "+
+			"resources would stay re-parented to the deleted inventory object's UID (%s)", originalRef.UID)
+	}
+}
+
+// TestPatchOwnerReferences_ReparentsOnInventoryRecreation drives
+// patchOwnerReferences against a fake REST client across a simulated
+// inventory deletion and recreation, and asserts that the PATCH sent for the
+// still-tracked object carries the recreated inventory's new UID while
+// leaving its pre-existing, unrelated owner reference alone.
+func TestPatchOwnerReferences_ReparentsOnInventoryRecreation(t *testing.T) {
+	trackedObj := object.ObjMetadata{
+		Namespace: "test-ns",
+		Name:      "cm1",
+		GroupKind: schema.GroupKind{Kind: "ConfigMap"},
+	}
+	otherOwnerRef := metav1.OwnerReference{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Name:       "some-operator",
+		UID:        types.UID("other-controller-uid"),
+	}
+	current := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "test-ns",
+			Name:            "cm1",
+			OwnerReferences: []metav1.OwnerReference{otherOwnerRef},
+		},
+	}
+
+	var patches [][]byte
+	codec := scheme.Codecs.LegacyCodec(corev1.SchemeGroupVersion)
+	client := &fake.RESTClient{
+		GroupVersion:         corev1.SchemeGroupVersion,
+		NegotiatedSerializer: scheme.Codecs.WithoutConversion(),
+		Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			switch p, m := req.URL.Path, req.Method; {
+			case p == "/namespaces/test-ns/configmaps/cm1" && m == "GET":
+				return &http.Response{StatusCode: http.StatusOK, Header: jsonHeader(), Body: objBody(codec, current)}, nil
+			case p == "/namespaces/test-ns/configmaps/cm1" && m == "PATCH":
+				body, err := ioutil.ReadAll(req.Body)
+				if err != nil {
+					t.Fatalf("reading patch body: %v", err)
+				}
+				patches = append(patches, body)
+				var merged struct {
+					Metadata struct {
+						OwnerReferences []metav1.OwnerReference `json:"ownerReferences"`
+					} `json:"metadata"`
+				}
+				if err := json.Unmarshal(body, &merged); err != nil {
+					t.Fatalf("unmarshaling patch body: %v", err)
+				}
+				updated := current.DeepCopy()
+				updated.OwnerReferences = merged.Metadata.OwnerReferences
+				current = updated
+				return &http.Response{StatusCode: http.StatusOK, Header: jsonHeader(), Body: objBody(codec, updated)}, nil
+			default:
+				t.Fatalf("unexpected request: %s %s", m, p)
+				return nil, nil
+			}
+		}),
+	}
+
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{corev1.SchemeGroupVersion})
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), meta.RESTScopeNamespace)
+
+	cic := &ClusterInventoryClient{
+		mapper:     mapper,
+		clientFunc: func(*meta.RESTMapping) (resource.RESTClient, error) { return client, nil },
+		policy:     InventoryPolicy{OwnerRefs: true},
+	}
+	objs := []object.ObjMetadata{trackedObj}
+
+	firstInv := fakeInventoryInfo("test-ns", "inventory", types.UID("original-uid"))
+	if err := cic.patchOwnerReferences(firstInv, objs); err != nil {
+		t.Fatalf("patchOwnerReferences (first) error = %v", err)
+	}
+	if len(patches) != 1 {
+		t.Fatalf("got %d patches after first merge, want 1", len(patches))
+	}
+	assertPatchOwnerRefs(t, patches[0], "inventory", "original-uid", otherOwnerRef)
+
+	// Simulate the inventory object being deleted and recreated under the
+	// same name but a new UID, then re-merging the same tracked object.
+	recreatedInv := fakeInventoryInfo("test-ns", "inventory", types.UID("recreated-uid"))
+	if err := cic.patchOwnerReferences(recreatedInv, objs); err != nil {
+		t.Fatalf("patchOwnerReferences (recreated) error = %v", err)
+	}
+	if len(patches) != 2 {
+		t.Fatalf("got %d patches after recreated merge, want 2", len(patches))
+	}
+	assertPatchOwnerRefs(t, patches[1], "inventory", "recreated-uid", otherOwnerRef)
+}
+
+// assertPatchOwnerRefs asserts that the JSON merge patch body sets an owner
+// reference named wantName with UID wantUID, and that it still carries
+// wantOther untouched.
+func assertPatchOwnerRefs(t *testing.T, patch []byte, wantName, wantUID string, wantOther metav1.OwnerReference) {
+	t.Helper()
+	var decoded struct {
+		Metadata struct {
+			OwnerReferences []metav1.OwnerReference `json:"ownerReferences"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal(patch, &decoded); err != nil {
+		t.Fatalf("unmarshaling patch: %v", err)
+	}
+	var sawInventoryRef, sawOtherRef bool
+	for _, ref := range decoded.Metadata.OwnerReferences {
+		if ref.Name == wantName {
+			sawInventoryRef = true
+			if string(ref.UID) != wantUID {
+				t.Errorf("owner reference %q UID = %q, want %q", wantName, ref.UID, wantUID)
+			}
+		}
+		if ref.Name == wantOther.Name && ref.UID == wantOther.UID {
+			sawOtherRef = true
+		}
+	}
+	if !sawInventoryRef {
+		t.Errorf("patch %s does not set an owner reference to %q", patch, wantName)
+	}
+	if !sawOtherRef {
+		t.Errorf("patch %s dropped the pre-existing owner reference to %q", patch, wantOther.Name)
+	}
+}
+
+func jsonHeader() http.Header {
+	h := http.Header{}
+	h.Set("Content-Type", runtime.ContentTypeJSON)
+	return h
+}
+
+func objBody(codec runtime.Codec, obj runtime.Object) io.ReadCloser {
+	return ioutil.NopCloser(bytes.NewReader([]byte(runtime.EncodeOrDie(codec, obj))))
+}